@@ -0,0 +1,49 @@
+// Copyright 2018 The Fuchsia Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+// Package conformance generates C++ conformance tests from a GIDL test
+// definition: one TEST() per encode/decode case, asserting that the
+// generated bindings round-trip the declared value against the declared
+// wire bytes. This gives the C++ backend the same cross-binding
+// conformance story as the upstream gidl tool.
+package conformance
+
+import (
+	"fidl/compiler/backend/gidl/ir"
+	"fidl/compiler/backend/types"
+	"os"
+	"path/filepath"
+	"text/template"
+)
+
+const ownerReadWriteNoExecute = 0644
+
+const conformanceFilename = "conformance_test.cc"
+
+// GenerateConformance compiles the parsed GIDL cases in gidl and emits a single
+// gtest source file, conformance_test.cc, under outputDir. fidl is the IR
+// of the FIDL library the cases exercise; it is accepted so future
+// revisions can resolve value literals against declared types, but is not
+// yet consulted by the value renderer.
+func GenerateConformance(gidl ir.All, fidl types.Root, outputDir string) error {
+	cases, err := compile(gidl)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(outputDir, ownerReadWriteNoExecute); err != nil {
+		return err
+	}
+
+	tmpls := template.New("ConformanceTemplates")
+	template.Must(tmpls.Parse(conformanceTemplate))
+
+	f, err := os.Create(filepath.Join(outputDir, conformanceFilename))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return tmpls.ExecuteTemplate(f, "GenerateConformanceTestsFile", cases)
+}
@@ -0,0 +1,94 @@
+// Copyright 2018 The Fuchsia Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package conformance
+
+import (
+	"fidl/compiler/backend/gidl/ir"
+)
+
+// testCase is the template-facing representation of a single conformance
+// check: a TEST() body asserting an encode/decode round trip.
+type testCase struct {
+	TestName    string
+	Value       string
+	Bytes       string
+	Handles     []string
+	ExpectError string
+	EncodeCheck bool
+	DecodeCheck bool
+	ExpectFail  bool
+}
+
+func compile(all ir.All) ([]testCase, error) {
+	var cases []testCase
+
+	for _, c := range all.EncodeSuccess {
+		value, err := renderValue(c.Value)
+		if err != nil {
+			return nil, err
+		}
+		cases = append(cases, testCase{
+			TestName:    sanitizeTestName(c.Name),
+			Value:       value,
+			Bytes:       renderBytes(c.Bytes),
+			Handles:     renderHandleDispositions(c.HandleDispositions),
+			EncodeCheck: true,
+		})
+	}
+	for _, c := range all.DecodeSuccess {
+		value, err := renderValue(c.Value)
+		if err != nil {
+			return nil, err
+		}
+		cases = append(cases, testCase{
+			TestName:    sanitizeTestName(c.Name),
+			Value:       value,
+			Bytes:       renderBytes(c.Bytes),
+			Handles:     renderHandleDispositions(c.HandleDispositions),
+			DecodeCheck: true,
+		})
+	}
+	for _, c := range all.EncodeFailure {
+		value, err := renderValue(c.Value)
+		if err != nil {
+			return nil, err
+		}
+		cases = append(cases, testCase{
+			TestName:    sanitizeTestName(c.Name),
+			Value:       value,
+			Handles:     renderHandleDispositions(c.HandleDispositions),
+			ExpectError: c.Err,
+			EncodeCheck: true,
+			ExpectFail:  true,
+		})
+	}
+	for _, c := range all.DecodeFailure {
+		cases = append(cases, testCase{
+			TestName:    sanitizeTestName(c.Name),
+			Bytes:       renderBytes(c.Bytes),
+			Handles:     renderHandleDispositions(c.HandleDispositions),
+			ExpectError: c.Err,
+			DecodeCheck: true,
+			ExpectFail:  true,
+		})
+	}
+
+	return cases, nil
+}
+
+// sanitizeTestName converts a GIDL case name (which may contain spaces or
+// slashes) into a valid gtest TEST() name component.
+func sanitizeTestName(name string) string {
+	out := make([]rune, 0, len(name))
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			out = append(out, r)
+		default:
+			out = append(out, '_')
+		}
+	}
+	return string(out)
+}
@@ -0,0 +1,66 @@
+// Copyright 2018 The Fuchsia Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package conformance
+
+import (
+	"fidl/compiler/backend/gidl/ir"
+	"testing"
+)
+
+func TestCompile(t *testing.T) {
+	all := ir.All{
+		EncodeSuccess: []ir.EncodeSuccess{
+			{
+				Name:  "basic case",
+				Value: int64(1),
+				Bytes: []byte{1, 0, 0, 0},
+				HandleDispositions: []ir.HandleDisposition{
+					{Handle: 0, Type: "CHANNEL", Rights: "TRANSFER"},
+				},
+			},
+		},
+		DecodeFailure: []ir.DecodeFailure{
+			{Name: "bad presence", Bytes: []byte{0xff}, Err: "INVALID_PRESENCE_INDICATOR"},
+		},
+	}
+
+	cases, err := compile(all)
+	if err != nil {
+		t.Fatalf("compile: %v", err)
+	}
+	if len(cases) != 2 {
+		t.Fatalf("got %d cases, want 2", len(cases))
+	}
+
+	if got, want := cases[0].TestName, "basic_case"; got != want {
+		t.Errorf("cases[0].TestName = %q, want %q", got, want)
+	}
+	if !cases[0].EncodeCheck || cases[0].DecodeCheck {
+		t.Errorf("cases[0]: EncodeCheck=%v DecodeCheck=%v, want encode-only", cases[0].EncodeCheck, cases[0].DecodeCheck)
+	}
+	if want := []string{"CreateTestHandle(ZX_OBJ_TYPE_CHANNEL, ZX_RIGHT_TRANSFER)"}; len(cases[0].Handles) != 1 || cases[0].Handles[0] != want[0] {
+		t.Errorf("cases[0].Handles = %v, want %v", cases[0].Handles, want)
+	}
+
+	if got, want := cases[1].ExpectError, "INVALID_PRESENCE_INDICATOR"; got != want {
+		t.Errorf("cases[1].ExpectError = %q, want %q", got, want)
+	}
+	if !cases[1].ExpectFail || !cases[1].DecodeCheck {
+		t.Errorf("cases[1]: ExpectFail=%v DecodeCheck=%v, want both true", cases[1].ExpectFail, cases[1].DecodeCheck)
+	}
+}
+
+func TestSanitizeTestName(t *testing.T) {
+	tests := []struct{ name, want string }{
+		{"basic case", "basic_case"},
+		{"a/b.c", "a_b_c"},
+		{"AlreadyValid123", "AlreadyValid123"},
+	}
+	for _, tc := range tests {
+		if got := sanitizeTestName(tc.name); got != tc.want {
+			t.Errorf("sanitizeTestName(%q) = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright 2018 The Fuchsia Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package conformance
+
+import (
+	"fidl/compiler/backend/gidl/ir"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// renderValue turns a GIDL value literal into a C++ aggregate initializer
+// expression for the corresponding generated type, e.g. a gidl Record
+// `MyStruct{field: 1}` becomes `MyStruct{.field = 1}`.
+func renderValue(value ir.Value) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", fmt.Errorf("missing value")
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64), nil
+	case string:
+		return strconv.Quote(v), nil
+	case ir.Identifier:
+		return renderIdentifier(v), nil
+	case ir.HandleRef:
+		return renderHandleRef(v), nil
+	case []byte:
+		return renderBytes(v), nil
+	case []ir.Value:
+		return renderList(v)
+	case ir.Record:
+		return renderRecord(v)
+	default:
+		return "", fmt.Errorf("unsupported value type %T", v)
+	}
+}
+
+// renderHandleRef renders a GIDL handle reference (e.g. `#0`) as an index
+// into the handles array supplied out-of-band to the generated test.
+func renderHandleRef(ref ir.HandleRef) string {
+	return fmt.Sprintf("handles[%d]", ref.Index)
+}
+
+// renderList renders a GIDL list literal (e.g. a list of records) as a C++
+// braced-init-list.
+func renderList(items []ir.Value) (string, error) {
+	var rendered []string
+	for i, item := range items {
+		s, err := renderValue(item)
+		if err != nil {
+			return "", fmt.Errorf("element %d: %v", i, err)
+		}
+		rendered = append(rendered, s)
+	}
+	return fmt.Sprintf("{%s}", strings.Join(rendered, ", ")), nil
+}
+
+// renderIdentifier renders a bare GIDL identifier (e.g. a reference to an
+// enum/bits constant) as a C++ scoped expression: `MyEnum.FOO` becomes
+// `MyEnum::FOO`.
+func renderIdentifier(id ir.Identifier) string {
+	return strings.ReplaceAll(string(id), ".", "::")
+}
+
+func renderRecord(r ir.Record) (string, error) {
+	var fields []string
+	for _, field := range r.Fields {
+		rendered, err := renderValue(field.Value)
+		if err != nil {
+			return "", fmt.Errorf("field %s.%s: %v", r.Name, field.Name, err)
+		}
+		fields = append(fields, fmt.Sprintf(".%s = %s", field.Name, rendered))
+	}
+	return fmt.Sprintf("%s{%s}", r.Name, strings.Join(fields, ", ")), nil
+}
+
+func renderBytes(bytes []byte) string {
+	hex := make([]string, len(bytes))
+	for i, b := range bytes {
+		hex[i] = fmt.Sprintf("0x%02x", b)
+	}
+	return fmt.Sprintf("std::vector<uint8_t>{%s}", strings.Join(hex, ", "))
+}
+
+// renderHandleDispositions renders the handles a GIDL case supplies
+// out-of-band, in index order, as one handle-creation expression per
+// element of the generated test's `handles` vector. Each handle is created
+// fresh with its declared rights so that a `#N` reference in the case's
+// Value resolves to a real handle at test time; the declared object type is
+// passed along, but CreateTestHandle always creates an event. zx::handle is
+// move-only, so callers must push_back these one at a
+// time rather than building a single braced-init-list.
+func renderHandleDispositions(hds []ir.HandleDisposition) []string {
+	handles := make([]string, len(hds))
+	for _, hd := range hds {
+		handles[hd.Handle] = fmt.Sprintf("CreateTestHandle(ZX_OBJ_TYPE_%s, ZX_RIGHT_%s)", hd.Type, hd.Rights)
+	}
+	return handles
+}
@@ -0,0 +1,76 @@
+// Copyright 2018 The Fuchsia Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package conformance
+
+import (
+	"fidl/compiler/backend/gidl/ir"
+	"testing"
+)
+
+func TestRenderValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		value ir.Value
+		want  string
+	}{
+		{"bool", true, "true"},
+		{"int", int64(42), "42"},
+		{"float", float64(1.5), "1.5"},
+		{"string", "hello", `"hello"`},
+		{"identifier", ir.Identifier("MyEnum.FOO"), "MyEnum::FOO"},
+		{"handle ref", ir.HandleRef{Index: 2}, "handles[2]"},
+		{"bytes", []byte{0x01, 0xff}, "std::vector<uint8_t>{0x01, 0xff}"},
+		{"list", []ir.Value{int64(1), ir.Identifier("A.B")}, "{1, A::B}"},
+		{
+			"record",
+			ir.Record{Name: "MyStruct", Fields: []ir.Field{{Name: "field", Value: int64(1)}}},
+			"MyStruct{.field = 1}",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := renderValue(tc.value)
+			if err != nil {
+				t.Fatalf("renderValue(%#v): unexpected error: %v", tc.value, err)
+			}
+			if got != tc.want {
+				t.Errorf("renderValue(%#v) = %q, want %q", tc.value, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRenderHandleDispositions(t *testing.T) {
+	hds := []ir.HandleDisposition{
+		{Handle: 1, Type: "EVENT", Rights: "DUPLICATE"},
+		{Handle: 0, Type: "CHANNEL", Rights: "TRANSFER"},
+	}
+	got := renderHandleDispositions(hds)
+	want := []string{
+		"CreateTestHandle(ZX_OBJ_TYPE_CHANNEL, ZX_RIGHT_TRANSFER)",
+		"CreateTestHandle(ZX_OBJ_TYPE_EVENT, ZX_RIGHT_DUPLICATE)",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("renderHandleDispositions(%+v) = %v, want %v", hds, got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("renderHandleDispositions(%+v)[%d] = %q, want %q", hds, i, got[i], want[i])
+		}
+	}
+}
+
+func TestRenderValueUnsupported(t *testing.T) {
+	if _, err := renderValue(uint32(1)); err == nil {
+		t.Error("renderValue(uint32): expected error for unsupported type, got none")
+	}
+}
+
+func TestRenderValueMissing(t *testing.T) {
+	if _, err := renderValue(nil); err == nil {
+		t.Error("renderValue(nil): expected error for missing value, got none")
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2018 The Fuchsia Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package conformance
+
+// conformanceTemplate renders one gtest TEST() per GIDL case, asserting that
+// fidl::Encode / fidl::Decode round-trip the declared value against the
+// declared wire bytes.
+const conformanceTemplate = `
+{{- define "GenerateConformanceTestsFile" -}}
+// WARNING: This file is machine generated by the conformance test generator.
+
+#include <vector>
+
+#include <gtest/gtest.h>
+#include <lib/zx/handle.h>
+#include <zircon/types.h>
+
+#include <fidl/cpp/internal/conversions.h>
+
+namespace conformance {
+
+namespace {
+
+// CreateTestHandle creates a fresh handle with the given rights, for a GIDL
+// case's handle dispositions to reference. The handle is always an event;
+// the object type is accepted but not consulted.
+zx::handle CreateTestHandle(zx_obj_type_t type, zx_rights_t rights) {
+  zx::event event;
+  zx::event::create(0, &event);
+  zx::handle handle;
+  zx_handle_replace(event.release(), rights, handle.reset_and_get_address());
+  return handle;
+}
+
+}  // namespace
+
+{{ range . }}
+TEST(Conformance, {{ .TestName }}) {
+{{- if .Handles }}
+  std::vector<zx::handle> handles;
+{{- range .Handles }}
+  handles.push_back({{ . }});
+{{- end }}
+{{- end }}
+{{- if .EncodeCheck }}
+  auto value = {{ .Value }};
+{{- if .ExpectFail }}
+  EXPECT_FALSE(fidl::Encode(value).ok());
+{{- else }}
+  auto result = fidl::Encode(value);
+  ASSERT_TRUE(result.ok());
+  EXPECT_EQ(result.bytes(), ({{ .Bytes }}));
+{{- end }}
+{{- end }}
+{{- if .DecodeCheck }}
+  std::vector<uint8_t> bytes = {{ .Bytes }};
+{{- if .ExpectFail }}
+  EXPECT_FALSE(fidl::Decode(bytes).ok());
+{{- else }}
+  auto result = fidl::Decode(bytes);
+  ASSERT_TRUE(result.ok());
+  EXPECT_EQ(result.value(), ({{ .Value }}));
+{{- end }}
+{{- end }}
+}
+{{ end }}
+
+}  // namespace conformance
+{{- end -}}
+`
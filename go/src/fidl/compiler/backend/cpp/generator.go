@@ -5,36 +5,153 @@
 package cpp
 
 import (
+	"bytes"
 	"fidl/compiler/backend/cpp/ir"
 	"fidl/compiler/backend/cpp/templates"
 	"fidl/compiler/backend/types"
+	"fidl/compiler/fidlgen"
+	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"text/template"
 )
 
-type FidlGenerator struct{}
+// CodeGenerationMode selects which subset of the generated C++ bindings
+// FidlGenerator emits. Splitting domain objects (the types the wire format
+// touches) from the messaging layer (proxies, stubs, event senders) lets
+// consumers that only need the types avoid pulling in the rest of the
+// bindings.
+type CodeGenerationMode int
+
+const (
+	// Monolithic emits domain objects and messaging code together, as a
+	// single generated.h / generated.cc pair. This is the historical
+	// behavior and remains the default.
+	Monolithic CodeGenerationMode = iota
+	// OnlyGenerateDomainObjects emits only enums, structs, and unions.
+	// Tables, bits, and request/response codecs are not generated.
+	OnlyGenerateDomainObjects
+	// OnlyGenerateProxiesAndStubs emits only the messaging layer: one proxy
+	// class per interface. Stubs and event senders are not generated.
+	OnlyGenerateProxiesAndStubs
+)
+
+// ParseCodeGenerationMode converts a -generate-mode flag value into a
+// CodeGenerationMode.
+func ParseCodeGenerationMode(s string) (CodeGenerationMode, error) {
+	switch s {
+	case "", "monolithic":
+		return Monolithic, nil
+	case "domain-objects-only":
+		return OnlyGenerateDomainObjects, nil
+	case "proxies-stubs-only":
+		return OnlyGenerateProxiesAndStubs, nil
+	default:
+		return Monolithic, fmt.Errorf("unknown code generation mode %q", s)
+	}
+}
+
+type FidlGenerator struct {
+	// Mode selects which subset of bindings to emit. The zero value is
+	// Monolithic, preserving the historical single generated.h/.cc output.
+	Mode CodeGenerationMode
+	// Formatter post-processes generated source before it is written to
+	// disk. A nil Formatter leaves the rendered output untouched.
+	Formatter fidlgen.Formatter
+	// CopyrightHeaderPath, if set, names a file whose contents are
+	// prepended verbatim to every emitted .h and .cc file.
+	CopyrightHeaderPath string
+	// JSONIRPath, if set, is recorded (together with CopyrightHeaderPath,
+	// if that is set) as an input in a depfile emitted alongside each
+	// generated output file, so that incremental builds can skip
+	// regenerating when the FIDL IR hasn't changed.
+	JSONIRPath string
+}
+
+// templateData bundles the compiled IR with generator-wide settings that
+// templates need but that aren't part of the FIDL library itself.
+type templateData struct {
+	ir.Root
+	CopyrightHeader string
+	// HeaderFilename is the name of the header this entry point's
+	// implementation file should #include. Unused for header entry points.
+	HeaderFilename string
+}
 
 const ownerReadWriteNoExecute = 0644
 
+// outputSpec pairs an output filename with the template used to render it.
+// headerFilename names the header an implementation-file template should
+// #include; it is empty for header-file specs, which don't include anything.
+type outputSpec struct {
+	filename       string
+	template       string
+	headerFilename string
+}
+
+func outputSpecs(mode CodeGenerationMode) []outputSpec {
+	switch mode {
+	case OnlyGenerateDomainObjects:
+		return []outputSpec{
+			{filename: "generated_types.h", template: "GenerateTypesHeaderFile"},
+			{filename: "generated_types.cc", template: "GenerateTypesImplementationFile", headerFilename: "generated_types.h"},
+		}
+	case OnlyGenerateProxiesAndStubs:
+		return []outputSpec{
+			{filename: "generated_messaging.h", template: "GenerateMessagingHeaderFile"},
+			{filename: "generated_messaging.cc", template: "GenerateMessagingImplementationFile", headerFilename: "generated_messaging.h"},
+		}
+	default:
+		return []outputSpec{
+			{filename: "generated.h", template: "GenerateHeaderFile"},
+			{filename: "generated.cc", template: "GenerateImplementationFile", headerFilename: "generated.h"},
+		}
+	}
+}
+
 func writeFile(outputFilename string,
 	templateName string,
 	tmpls *template.Template,
-	tree ir.Root) error {
-	f, err := os.Create(outputFilename)
+	data templateData,
+	formatter fidlgen.Formatter) error {
+	var buf bytes.Buffer
+	if err := tmpls.ExecuteTemplate(&buf, templateName, data); err != nil {
+		return err
+	}
+
+	rendered := buf.Bytes()
+	if formatter != nil {
+		formatted, err := formatter.Format(rendered)
+		if err != nil {
+			return err
+		}
+		rendered = formatted
+	}
+
+	writer, err := fidlgen.NewLazyWriter(outputFilename)
 	if err != nil {
 		return err
 	}
-	defer f.Close()
-	return tmpls.ExecuteTemplate(f, templateName, tree)
+	return writer.WriteFile(rendered)
 }
 
-func (_ FidlGenerator) GenerateFidl(
+func (gen FidlGenerator) GenerateFidl(
 	data types.Root, _args []string,
 	outputDir string, srcRootPath string) error {
 
 	tree := ir.Compile(data)
 
+	var copyrightHeader string
+	if gen.CopyrightHeaderPath != "" {
+		header, err := ioutil.ReadFile(gen.CopyrightHeaderPath)
+		if err != nil {
+			return err
+		}
+		copyrightHeader = string(header)
+	}
+	tmplData := templateData{Root: tree, CopyrightHeader: copyrightHeader}
+
 	parentDir := filepath.Join(outputDir, srcRootPath)
 	err := os.MkdirAll(parentDir, ownerReadWriteNoExecute)
 	if err != nil {
@@ -48,17 +165,35 @@ func (_ FidlGenerator) GenerateFidl(
 	template.Must(tmpls.Parse(templates.Interface))
 	template.Must(tmpls.Parse(templates.Struct))
 	template.Must(tmpls.Parse(templates.Union))
+	template.Must(tmpls.Parse(templates.GenerateHeaderFile))
+	template.Must(tmpls.Parse(templates.GenerateImplementationFile))
+	template.Must(tmpls.Parse(templates.GenerateTypesHeaderFile))
+	template.Must(tmpls.Parse(templates.GenerateTypesImplementationFile))
+	template.Must(tmpls.Parse(templates.GenerateMessagingHeaderFile))
+	template.Must(tmpls.Parse(templates.GenerateMessagingImplementationFile))
 
-	outputFilename := filepath.Join(parentDir, "generated.h")
-	err = writeFile(outputFilename, "GenerateHeaderFile", tmpls, tree)
-	if err != nil {
-		return err
+	var outputFilenames []string
+	for _, spec := range outputSpecs(gen.Mode) {
+		outputFilename := filepath.Join(parentDir, spec.filename)
+		data := tmplData
+		data.HeaderFilename = spec.headerFilename
+		if err := writeFile(outputFilename, spec.template, tmpls, data, gen.Formatter); err != nil {
+			return err
+		}
+		outputFilenames = append(outputFilenames, outputFilename)
 	}
 
-	outputFilename = filepath.Join(parentDir, "generated.cc")
-	err = writeFile(outputFilename, "GenerateImplementationFile", tmpls, tree)
-	if err != nil {
-		return err
+	if gen.JSONIRPath != "" {
+		inputs := []string{gen.JSONIRPath}
+		if gen.CopyrightHeaderPath != "" {
+			inputs = append(inputs, gen.CopyrightHeaderPath)
+		}
+		for _, outputFilename := range outputFilenames {
+			depfilePath := outputFilename + ".d"
+			if err := fidlgen.WriteDepFile(depfilePath, outputFilename, inputs); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
@@ -0,0 +1,107 @@
+// Copyright 2018 The Fuchsia Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+// Package ir defines the C++-backend-specific intermediate representation
+// compiled from a FIDL library's types.Root. It splits domain-object
+// declarations (enums, structs, unions) from messaging declarations
+// (interfaces) so that the cpp package can generate either subset
+// independently of the other.
+package ir
+
+import "fidl/compiler/backend/types"
+
+// Root is a compiled FIDL library, ready for C++ template rendering.
+type Root struct {
+	LibraryName string
+	Enums       []Enum
+	Structs     []Struct
+	Unions      []Union
+	Interfaces  []Interface
+}
+
+// Enum is a C++ `enum class` declaration.
+type Enum struct {
+	Name    string
+	Members []EnumMember
+}
+
+// EnumMember is a single `Name = Value` entry within an Enum.
+type EnumMember struct {
+	Name  string
+	Value string
+}
+
+// Struct is a C++ struct declaration.
+type Struct struct {
+	Name    string
+	Members []StructMember
+}
+
+// StructMember is a single typed field within a Struct.
+type StructMember struct {
+	Name string
+	Type string
+}
+
+// Union is a C++ tagged-union declaration.
+type Union struct {
+	Name    string
+	Members []UnionMember
+}
+
+// UnionMember is a single typed variant within a Union.
+type UnionMember struct {
+	Name string
+	Type string
+}
+
+// Interface is a FIDL protocol, compiled to its C++ proxy/stub methods.
+type Interface struct {
+	Name    string
+	Methods []Method
+}
+
+// Method is a single request within an Interface.
+type Method struct {
+	Name string
+}
+
+// Compile translates a parsed FIDL library into its C++ backend IR.
+func Compile(r types.Root) Root {
+	root := Root{LibraryName: r.Name}
+
+	for _, e := range r.Enums {
+		enum := Enum{Name: e.Name}
+		for _, m := range e.Members {
+			enum.Members = append(enum.Members, EnumMember{Name: m.Name, Value: m.Value})
+		}
+		root.Enums = append(root.Enums, enum)
+	}
+
+	for _, s := range r.Structs {
+		st := Struct{Name: s.Name}
+		for _, m := range s.Members {
+			st.Members = append(st.Members, StructMember{Name: m.Name, Type: m.Type})
+		}
+		root.Structs = append(root.Structs, st)
+	}
+
+	for _, u := range r.Unions {
+		un := Union{Name: u.Name}
+		for _, m := range u.Members {
+			un.Members = append(un.Members, UnionMember{Name: m.Name, Type: m.Type})
+		}
+		root.Unions = append(root.Unions, un)
+	}
+
+	for _, i := range r.Interfaces {
+		iface := Interface{Name: i.Name}
+		for _, m := range i.Methods {
+			iface.Methods = append(iface.Methods, Method{Name: m.Name})
+		}
+		root.Interfaces = append(root.Interfaces, iface)
+	}
+
+	return root
+}
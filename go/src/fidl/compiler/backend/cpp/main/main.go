@@ -0,0 +1,73 @@
+// Copyright 2018 The Fuchsia Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+// Command fidlgen_cpp drives the C++ backend: it reads a FIDL intermediate
+// representation and emits the corresponding generated.h / generated.cc
+// (or generated_types.* / generated_messaging.* in split mode) files.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"fidl/compiler/backend/cpp"
+	"fidl/compiler/backend/types"
+	"fidl/compiler/fidlgen"
+)
+
+var (
+	jsonPath = flag.String("json", "",
+		"relative path to the FIDL intermediate representation")
+	outputDir = flag.String("output-dir", "",
+		"directory to write generated files into")
+	includePath = flag.String("include-path", "",
+		"path, relative to output-dir, under which generated files are written")
+	generateMode = flag.String("generate-mode", "monolithic",
+		"which bindings to emit: monolithic, domain-objects-only, or proxies-stubs-only")
+	clangFormatPath = flag.String("clang-format-path", "",
+		"path to a clang-format binary used to format generated output; if empty, output is left unformatted")
+	copyrightHeaderFile = flag.String("copyright-header-file", "",
+		"path to a file whose contents are prepended verbatim to every emitted .h and .cc file")
+)
+
+func main() {
+	flag.Parse()
+
+	if *jsonPath == "" {
+		fmt.Fprintln(os.Stderr, "-json is required")
+		os.Exit(1)
+	}
+
+	mode, err := cpp.ParseCodeGenerationMode(*generateMode)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	bytes, err := ioutil.ReadFile(*jsonPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to read %s: %v\n", *jsonPath, err)
+		os.Exit(1)
+	}
+
+	var root types.Root
+	if err := json.Unmarshal(bytes, &root); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to parse %s: %v\n", *jsonPath, err)
+		os.Exit(1)
+	}
+
+	generator := cpp.FidlGenerator{
+		Mode:                mode,
+		Formatter:           fidlgen.NewClangFormatFormatter(*clangFormatPath),
+		CopyrightHeaderPath: *copyrightHeaderFile,
+		JSONIRPath:          *jsonPath,
+	}
+	if err := generator.GenerateFidl(root, flag.Args(), *outputDir, *includePath); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to generate fidl: %v\n", err)
+		os.Exit(1)
+	}
+}
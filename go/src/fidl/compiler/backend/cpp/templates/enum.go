@@ -0,0 +1,19 @@
+// Copyright 2018 The Fuchsia Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package templates
+
+// Enum renders every enum in the library as a C++ `enum class`.
+const Enum = `
+{{- define "Enum" -}}
+{{ .CopyrightHeader }}
+{{- range .Enums }}
+enum class {{ .Name }} {
+{{- range .Members }}
+  {{ .Name }} = {{ .Value }},
+{{- end }}
+};
+{{ end -}}
+{{- end -}}
+`
@@ -0,0 +1,49 @@
+// Copyright 2018 The Fuchsia Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package templates
+
+// Header renders the shared `#pragma once` / `#include` boilerplate common
+// to every generated C++ header file.
+const Header = `
+{{- define "Header" -}}
+{{ .CopyrightHeader }}
+#pragma once
+
+#include <cstdint>
+#include <vector>
+{{ end -}}
+`
+
+// GenerateHeaderFile is the monolithic-mode header entry point: domain
+// objects and messaging declarations together in a single generated.h.
+const GenerateHeaderFile = `
+{{- define "GenerateHeaderFile" -}}
+{{ template "Header" . }}
+{{ template "Enum" . }}
+{{ template "Struct" . }}
+{{ template "Union" . }}
+{{ template "Interface" . }}
+{{- end -}}
+`
+
+// GenerateTypesHeaderFile is the domain-objects-only header entry point:
+// enums, structs, and unions, with no messaging declarations.
+const GenerateTypesHeaderFile = `
+{{- define "GenerateTypesHeaderFile" -}}
+{{ template "Header" . }}
+{{ template "Enum" . }}
+{{ template "Struct" . }}
+{{ template "Union" . }}
+{{- end -}}
+`
+
+// GenerateMessagingHeaderFile is the proxies-and-stubs-only header entry
+// point: interfaces only, with no domain-object declarations.
+const GenerateMessagingHeaderFile = `
+{{- define "GenerateMessagingHeaderFile" -}}
+{{ template "Header" . }}
+{{ template "Interface" . }}
+{{- end -}}
+`
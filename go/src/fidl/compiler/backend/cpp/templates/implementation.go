@@ -0,0 +1,39 @@
+// Copyright 2018 The Fuchsia Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package templates
+
+// Implementation renders the shared `#include` boilerplate common to every
+// generated C++ implementation file.
+const Implementation = `
+{{- define "Implementation" -}}
+{{ .CopyrightHeader }}
+#include "{{ .HeaderFilename }}"
+{{ end -}}
+`
+
+// GenerateImplementationFile is the monolithic-mode implementation entry
+// point, generated.cc. Like the split-mode entry points below, it emits
+// only the copyright header and the #include of its header.
+const GenerateImplementationFile = `
+{{- define "GenerateImplementationFile" -}}
+{{ template "Implementation" . }}
+{{- end -}}
+`
+
+// GenerateTypesImplementationFile is the domain-objects-only
+// implementation entry point.
+const GenerateTypesImplementationFile = `
+{{- define "GenerateTypesImplementationFile" -}}
+{{ template "Implementation" . }}
+{{- end -}}
+`
+
+// GenerateMessagingImplementationFile is the proxies-and-stubs-only
+// implementation entry point.
+const GenerateMessagingImplementationFile = `
+{{- define "GenerateMessagingImplementationFile" -}}
+{{ template "Implementation" . }}
+{{- end -}}
+`
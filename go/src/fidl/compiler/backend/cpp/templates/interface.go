@@ -0,0 +1,21 @@
+// Copyright 2018 The Fuchsia Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package templates
+
+// Interface renders every interface in the library as a C++ proxy class
+// with one pure virtual method per FIDL method. No stub class is rendered.
+const Interface = `
+{{- define "Interface" -}}
+{{ .CopyrightHeader }}
+{{- range .Interfaces }}
+class {{ .Name }}_Proxy {
+ public:
+{{- range .Methods }}
+  virtual void {{ .Name }}() = 0;
+{{- end }}
+};
+{{ end -}}
+{{- end -}}
+`
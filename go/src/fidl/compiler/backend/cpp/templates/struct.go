@@ -0,0 +1,19 @@
+// Copyright 2018 The Fuchsia Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package templates
+
+// Struct renders every struct in the library as a C++ struct.
+const Struct = `
+{{- define "Struct" -}}
+{{ .CopyrightHeader }}
+{{- range .Structs }}
+struct {{ .Name }} {
+{{- range .Members }}
+  {{ .Type }} {{ .Name }};
+{{- end }}
+};
+{{ end -}}
+{{- end -}}
+`
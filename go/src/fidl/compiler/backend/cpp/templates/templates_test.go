@@ -0,0 +1,94 @@
+// Copyright 2018 The Fuchsia Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package templates
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"text/template"
+)
+
+// data mirrors the subset of cpp.templateData (ir.Root plus CopyrightHeader)
+// that these templates render against. It's declared here, rather than
+// imported, so this package can be built and tested without depending on
+// cpp/ir (which in turn depends on the FIDL types.Root IR).
+type data struct {
+	CopyrightHeader string
+	HeaderFilename  string
+	Enums           []struct {
+		Name    string
+		Members []struct{ Name, Value string }
+	}
+	Structs    []struct{}
+	Unions     []struct{}
+	Interfaces []struct{}
+}
+
+func parseAll(t *testing.T) *template.Template {
+	t.Helper()
+	tmpls := template.New("CPPTemplates")
+	for _, src := range []string{
+		Enum, Header, Implementation, Interface, Struct, Union,
+		GenerateHeaderFile, GenerateImplementationFile,
+		GenerateTypesHeaderFile, GenerateTypesImplementationFile,
+		GenerateMessagingHeaderFile, GenerateMessagingImplementationFile,
+	} {
+		if _, err := tmpls.Parse(src); err != nil {
+			t.Fatalf("Parse: %v", err)
+		}
+	}
+	return tmpls
+}
+
+func TestCopyrightHeaderIsEmitted(t *testing.T) {
+	d := data{CopyrightHeader: "// COPYRIGHT SENTINEL\n"}
+
+	for _, templateName := range []string{
+		"GenerateHeaderFile",
+		"GenerateImplementationFile",
+		"GenerateTypesHeaderFile",
+		"GenerateTypesImplementationFile",
+		"GenerateMessagingHeaderFile",
+		"GenerateMessagingImplementationFile",
+	} {
+		t.Run(templateName, func(t *testing.T) {
+			tmpls := parseAll(t)
+			var buf bytes.Buffer
+			if err := tmpls.ExecuteTemplate(&buf, templateName, d); err != nil {
+				t.Fatalf("ExecuteTemplate(%s): %v", templateName, err)
+			}
+			if !strings.Contains(buf.String(), "COPYRIGHT SENTINEL") {
+				t.Errorf("%s output does not contain the configured copyright header:\n%s", templateName, buf.String())
+			}
+		})
+	}
+}
+
+func TestImplementationIncludesMatchingHeader(t *testing.T) {
+	tests := []struct {
+		templateName   string
+		headerFilename string
+	}{
+		{"GenerateImplementationFile", "generated.h"},
+		{"GenerateTypesImplementationFile", "generated_types.h"},
+		{"GenerateMessagingImplementationFile", "generated_messaging.h"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.templateName, func(t *testing.T) {
+			tmpls := parseAll(t)
+			d := data{HeaderFilename: tc.headerFilename}
+			var buf bytes.Buffer
+			if err := tmpls.ExecuteTemplate(&buf, tc.templateName, d); err != nil {
+				t.Fatalf("ExecuteTemplate(%s): %v", tc.templateName, err)
+			}
+			want := `#include "` + tc.headerFilename + `"`
+			if !strings.Contains(buf.String(), want) {
+				t.Errorf("%s output does not %s, got:\n%s", tc.templateName, want, buf.String())
+			}
+		})
+	}
+}
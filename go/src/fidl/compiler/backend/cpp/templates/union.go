@@ -0,0 +1,20 @@
+// Copyright 2018 The Fuchsia Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package templates
+
+// Union renders every union in the library as a C++ tagged union.
+const Union = `
+{{- define "Union" -}}
+{{ .CopyrightHeader }}
+{{- range .Unions }}
+class {{ .Name }} {
+ public:
+{{- range .Members }}
+  {{ .Type }} {{ .Name }};
+{{- end }}
+};
+{{ end -}}
+{{- end -}}
+`
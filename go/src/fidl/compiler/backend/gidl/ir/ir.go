@@ -0,0 +1,89 @@
+// Copyright 2018 The Fuchsia Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+// Package ir defines the in-memory representation of a parsed GIDL test
+// definition file: a set of encode/decode conformance cases shared across
+// language bindings.
+package ir
+
+// All is the parsed contents of a single GIDL file.
+type All struct {
+	EncodeSuccess []EncodeSuccess
+	DecodeSuccess []DecodeSuccess
+	EncodeFailure []EncodeFailure
+	DecodeFailure []DecodeFailure
+}
+
+// EncodeSuccess asserts that encoding Value produces Bytes, with the given
+// handles crossing the wire as described by HandleDispositions.
+type EncodeSuccess struct {
+	Name               string
+	Value              Value
+	Bytes              []byte
+	HandleDispositions []HandleDisposition
+}
+
+// DecodeSuccess asserts that decoding Bytes (and HandleDispositions' handles)
+// as Value's type produces Value.
+type DecodeSuccess struct {
+	Name               string
+	Value              Value
+	Bytes              []byte
+	HandleDispositions []HandleDisposition
+}
+
+// EncodeFailure asserts that encoding Value fails with Err.
+type EncodeFailure struct {
+	Name               string
+	Value              Value
+	Err                string
+	HandleDispositions []HandleDisposition
+}
+
+// DecodeFailure asserts that decoding Bytes fails with Err.
+type DecodeFailure struct {
+	Name               string
+	Bytes              []byte
+	Err                string
+	HandleDispositions []HandleDisposition
+}
+
+// Value is a GIDL value literal: a Record (struct/union/table initializer),
+// an Identifier (a bare name, e.g. a reference to an enum/bits constant), a
+// HandleRef (a reference to one of the handles supplied out-of-band to the
+// test), a slice of Values (a list literal), or a Go primitive (bool,
+// int64, float64, string).
+type Value interface{}
+
+// Record is a named aggregate literal, e.g. `MyStruct{field: 1, other: 2}`.
+type Record struct {
+	Name   string
+	Fields []Field
+}
+
+// Field is a single `name: value` pair within a Record.
+type Field struct {
+	Name  string
+	Value Value
+}
+
+// Identifier is a bare (unquoted) name appearing in value position, e.g.
+// the `MyEnum.FOO` in `value: MyEnum.FOO`. Unlike a string literal, an
+// identifier renders as a bare expression rather than a quoted string.
+type Identifier string
+
+// HandleRef is a reference to one of the handles supplied out-of-band to a
+// test case by index, e.g. the `#0` in `value: SomeHandleHolder{h: #0}`.
+type HandleRef struct {
+	Index int
+}
+
+// HandleDisposition describes how a single handle is expected to cross the
+// wire during an encode or decode case: which handle (by index), and the
+// object type and rights it should carry.
+type HandleDisposition struct {
+	Handle int
+	Type   string
+	Rights string
+}
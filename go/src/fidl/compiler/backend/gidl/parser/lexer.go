@@ -0,0 +1,209 @@
+// Copyright 2018 The Fuchsia Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package parser
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tEOF tokenKind = iota
+	tIdentifier
+	tInt
+	tFloat
+	tString
+	tHandle
+	tLBrace
+	tRBrace
+	tLBracket
+	tRBracket
+	tColon
+	tComma
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	line int
+}
+
+// lexer turns GIDL source text into a stream of tokens.
+type lexer struct {
+	input string
+	pos   int
+	line  int
+}
+
+func newLexer(input string) *lexer {
+	return &lexer{input: input, line: 1}
+}
+
+func (l *lexer) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("line %d: %s", l.line, fmt.Sprintf(format, args...))
+}
+
+func (l *lexer) peekRune() (rune, int) {
+	if l.pos >= len(l.input) {
+		return 0, 0
+	}
+	return utf8.DecodeRuneInString(l.input[l.pos:])
+}
+
+func (l *lexer) next() (token, error) {
+	l.skipSpaceAndComments()
+	if l.pos >= len(l.input) {
+		return token{kind: tEOF, line: l.line}, nil
+	}
+
+	r, size := l.peekRune()
+	line := l.line
+
+	switch {
+	case r == '{':
+		l.pos += size
+		return token{kind: tLBrace, text: "{", line: line}, nil
+	case r == '}':
+		l.pos += size
+		return token{kind: tRBrace, text: "}", line: line}, nil
+	case r == ':':
+		l.pos += size
+		return token{kind: tColon, text: ":", line: line}, nil
+	case r == ',':
+		l.pos += size
+		return token{kind: tComma, text: ",", line: line}, nil
+	case r == '[':
+		l.pos += size
+		return token{kind: tLBracket, text: "[", line: line}, nil
+	case r == ']':
+		l.pos += size
+		return token{kind: tRBracket, text: "]", line: line}, nil
+	case r == '"':
+		return l.lexString()
+	case r == '#':
+		return l.lexHandle()
+	case r == '-' || unicode.IsDigit(r):
+		return l.lexNumber()
+	case isIdentStart(r):
+		return l.lexIdentifier()
+	default:
+		return token{}, l.errorf("unexpected character %q", r)
+	}
+}
+
+func (l *lexer) skipSpaceAndComments() {
+	for l.pos < len(l.input) {
+		r, size := l.peekRune()
+		if r == '\n' {
+			l.line++
+			l.pos += size
+			continue
+		}
+		if unicode.IsSpace(r) {
+			l.pos += size
+			continue
+		}
+		if r == '/' && strings.HasPrefix(l.input[l.pos:], "//") {
+			for l.pos < len(l.input) && l.input[l.pos] != '\n' {
+				l.pos++
+			}
+			continue
+		}
+		break
+	}
+}
+
+func isIdentStart(r rune) bool {
+	return unicode.IsLetter(r) || r == '_'
+}
+
+func isIdentRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.'
+}
+
+func (l *lexer) lexIdentifier() (token, error) {
+	start, line := l.pos, l.line
+	for l.pos < len(l.input) {
+		r, size := l.peekRune()
+		if !isIdentRune(r) {
+			break
+		}
+		l.pos += size
+	}
+	return token{kind: tIdentifier, text: l.input[start:l.pos], line: line}, nil
+}
+
+func (l *lexer) lexNumber() (token, error) {
+	start, line := l.pos, l.line
+	if r, size := l.peekRune(); r == '-' {
+		l.pos += size
+	}
+
+	if strings.HasPrefix(l.input[l.pos:], "0x") || strings.HasPrefix(l.input[l.pos:], "0X") {
+		l.pos += 2
+		for l.pos < len(l.input) && isHexDigit(l.input[l.pos]) {
+			l.pos++
+		}
+		return token{kind: tInt, text: l.input[start:l.pos], line: line}, nil
+	}
+
+	isFloat := false
+	for l.pos < len(l.input) {
+		r, size := l.peekRune()
+		if unicode.IsDigit(r) {
+			l.pos += size
+			continue
+		}
+		if r == '.' && !isFloat {
+			isFloat = true
+			l.pos += size
+			continue
+		}
+		break
+	}
+	kind := tInt
+	if isFloat {
+		kind = tFloat
+	}
+	return token{kind: kind, text: l.input[start:l.pos], line: line}, nil
+}
+
+func isHexDigit(b byte) bool {
+	return (b >= '0' && b <= '9') || (b >= 'a' && b <= 'f') || (b >= 'A' && b <= 'F')
+}
+
+func (l *lexer) lexString() (token, error) {
+	line := l.line
+	l.pos++ // opening quote
+	start := l.pos
+	for l.pos < len(l.input) && l.input[l.pos] != '"' {
+		l.pos++
+	}
+	if l.pos >= len(l.input) {
+		return token{}, l.errorf("unterminated string literal")
+	}
+	text := l.input[start:l.pos]
+	l.pos++ // closing quote
+	return token{kind: tString, text: text, line: line}, nil
+}
+
+// lexHandle consumes a handle-reference literal of the form #0, referencing
+// one of the handles supplied out-of-band to the test by index.
+func (l *lexer) lexHandle() (token, error) {
+	line := l.line
+	l.pos++ // '#'
+	start := l.pos
+	for l.pos < len(l.input) && unicode.IsDigit(rune(l.input[l.pos])) {
+		l.pos++
+	}
+	if l.pos == start {
+		return token{}, l.errorf("expected a handle index after '#'")
+	}
+	return token{kind: tHandle, text: l.input[start:l.pos], line: line}, nil
+}
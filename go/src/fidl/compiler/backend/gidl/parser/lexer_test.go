@@ -0,0 +1,58 @@
+// Copyright 2018 The Fuchsia Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package parser
+
+import "testing"
+
+func TestLexerTokens(t *testing.T) {
+	input := `foo.BAR 42 -1 0xFF 1.5 "a string" #3 { } [ ] : , // comment
+`
+	want := []tokenKind{
+		tIdentifier, tInt, tInt, tInt, tFloat, tString, tHandle,
+		tLBrace, tRBrace, tLBracket, tRBracket, tColon, tComma, tEOF,
+	}
+
+	l := newLexer(input)
+	for i, wantKind := range want {
+		tok, err := l.next()
+		if err != nil {
+			t.Fatalf("token %d: unexpected error: %v", i, err)
+		}
+		if tok.kind != wantKind {
+			t.Errorf("token %d: kind = %v, want %v (text %q)", i, tok.kind, wantKind, tok.text)
+		}
+	}
+}
+
+func TestLexerErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"unterminated string", `"abc`},
+		{"dangling hash", `#`},
+		{"unexpected character", `?`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			l := newLexer(tc.input)
+			if _, err := l.next(); err == nil {
+				t.Errorf("next(%q): expected error, got none", tc.input)
+			}
+		})
+	}
+}
+
+func TestLexerHexByteLiteral(t *testing.T) {
+	l := newLexer(`0xff`)
+	tok, err := l.next()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tok.kind != tInt || tok.text != "0xff" {
+		t.Errorf("got kind=%v text=%q, want tInt \"0xff\"", tok.kind, tok.text)
+	}
+}
@@ -0,0 +1,318 @@
+// Copyright 2018 The Fuchsia Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package parser
+
+import (
+	"fidl/compiler/backend/gidl/ir"
+	"fmt"
+	"strconv"
+)
+
+// parser parses the contents of a GIDL test-definition file into its IR.
+//
+// A GIDL file is a sequence of top-level blocks, each one of encode_success,
+// decode_success, encode_failure or decode_failure, holding `key: value`
+// fields:
+//
+//	encode_success {
+//	    name: "name",
+//	    value: MyStruct{field: 1},
+//	    bytes: [1, 0, 0, 0],
+//	}
+//	decode_failure {
+//	    name: "name",
+//	    bytes: [0xff],
+//	    err: INVALID_PRESENCE_INDICATOR,
+//	}
+type parser struct {
+	lex *lexer
+	tok token
+}
+
+// Parse parses a complete GIDL file.
+func Parse(input string) (ir.All, error) {
+	p := &parser{lex: newLexer(input)}
+	if err := p.advance(); err != nil {
+		return ir.All{}, err
+	}
+
+	var all ir.All
+	for p.tok.kind != tEOF {
+		if err := p.parseBlock(&all); err != nil {
+			return ir.All{}, err
+		}
+	}
+	return all, nil
+}
+
+func (p *parser) advance() error {
+	tok, err := p.lex.next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+func (p *parser) expect(kind tokenKind, what string) (token, error) {
+	if p.tok.kind != kind {
+		return token{}, p.lex.errorf("expected %s, got %q", what, p.tok.text)
+	}
+	tok := p.tok
+	return tok, p.advance()
+}
+
+func (p *parser) parseBlock(all *ir.All) error {
+	kind, err := p.expect(tIdentifier, "block kind")
+	if err != nil {
+		return err
+	}
+
+	if _, err := p.expect(tLBrace, "'{' after block name (parenthesized names are not yet supported)"); err == nil {
+		return p.parseBlockBody(all, kind.text, "")
+	}
+
+	return fmt.Errorf("line %d: unsupported block syntax for %q", kind.line, kind.text)
+}
+
+func (p *parser) parseBlockBody(all *ir.All, kind, name string) error {
+	fields := map[string]interface{}{}
+	for p.tok.kind != tRBrace {
+		key, err := p.expect(tIdentifier, "field name")
+		if err != nil {
+			return err
+		}
+		if _, err := p.expect(tColon, "':'"); err != nil {
+			return err
+		}
+		value, err := p.parseValue()
+		if err != nil {
+			return err
+		}
+		fields[key.text] = value
+		if p.tok.kind == tComma {
+			if err := p.advance(); err != nil {
+				return err
+			}
+		}
+	}
+	if _, err := p.expect(tRBrace, "'}'"); err != nil {
+		return err
+	}
+
+	caseName := valueAsString(fields["name"])
+	errVal := valueAsString(fields["err"])
+	bytesVal, err := toByteSlice(fields["bytes"])
+	if err != nil {
+		return fmt.Errorf("field bytes: %v", err)
+	}
+	handleDispositions, err := toHandleDispositions(fields["handle_dispositions"])
+	if err != nil {
+		return fmt.Errorf("field handle_dispositions: %v", err)
+	}
+
+	switch kind {
+	case "encode_success":
+		all.EncodeSuccess = append(all.EncodeSuccess, ir.EncodeSuccess{
+			Name: caseName, Value: fields["value"], Bytes: bytesVal, HandleDispositions: handleDispositions,
+		})
+	case "decode_success":
+		all.DecodeSuccess = append(all.DecodeSuccess, ir.DecodeSuccess{
+			Name: caseName, Value: fields["value"], Bytes: bytesVal, HandleDispositions: handleDispositions,
+		})
+	case "encode_failure":
+		all.EncodeFailure = append(all.EncodeFailure, ir.EncodeFailure{
+			Name: caseName, Value: fields["value"], Err: errVal, HandleDispositions: handleDispositions,
+		})
+	case "decode_failure":
+		all.DecodeFailure = append(all.DecodeFailure, ir.DecodeFailure{
+			Name: caseName, Bytes: bytesVal, Err: errVal, HandleDispositions: handleDispositions,
+		})
+	default:
+		return fmt.Errorf("unknown block kind %q", kind)
+	}
+	return nil
+}
+
+func (p *parser) parseValue() (ir.Value, error) {
+	switch p.tok.kind {
+	case tString:
+		s := p.tok.text
+		return s, p.advance()
+	case tInt:
+		text := p.tok.text
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		n, err := strconv.ParseInt(text, 0, 64)
+		if err != nil {
+			return nil, err
+		}
+		return n, nil
+	case tFloat:
+		text := p.tok.text
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		return strconv.ParseFloat(text, 64)
+	case tHandle:
+		text := p.tok.text
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		n, err := strconv.Atoi(text)
+		if err != nil {
+			return nil, err
+		}
+		return ir.HandleRef{Index: n}, nil
+	case tLBracket:
+		return p.parseList()
+	case tIdentifier:
+		return p.parseIdentifierValue()
+	default:
+		return nil, p.lex.errorf("unexpected token %q in value position", p.tok.text)
+	}
+}
+
+func (p *parser) parseIdentifierValue() (ir.Value, error) {
+	name := p.tok.text
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	switch name {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	}
+	if p.tok.kind != tLBrace {
+		// A bare identifier, e.g. a reference to an enum/bits constant or
+		// an error constant name. Kept distinct from a string literal (ir.
+		// Identifier rather than string) so it renders as a bare
+		// expression instead of a quoted string.
+		return ir.Identifier(name), nil
+	}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	var fields []ir.Field
+	for p.tok.kind != tRBrace {
+		key, err := p.expect(tIdentifier, "field name")
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tColon, "':'"); err != nil {
+			return nil, err
+		}
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, ir.Field{Name: key.text, Value: value})
+		if p.tok.kind == tComma {
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if _, err := p.expect(tRBrace, "'}'"); err != nil {
+		return nil, err
+	}
+	return ir.Record{Name: name, Fields: fields}, nil
+}
+
+// valueAsString extracts the textual name of a string literal or bare
+// identifier value, or "" if v is nil or of another kind.
+func valueAsString(v ir.Value) string {
+	switch t := v.(type) {
+	case string:
+		return t
+	case ir.Identifier:
+		return string(t)
+	default:
+		return ""
+	}
+}
+
+// parseList parses a bracketed, comma-separated value list, e.g. a byte
+// array literal `[1, 0, 0xff]` or a list of handle disposition records.
+func (p *parser) parseList() (ir.Value, error) {
+	if _, err := p.expect(tLBracket, "'['"); err != nil {
+		return nil, err
+	}
+	var items []ir.Value
+	for p.tok.kind != tRBracket {
+		item, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+		if p.tok.kind == tComma {
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if _, err := p.expect(tRBracket, "']'"); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// toByteSlice converts a parsed `[1, 2, 0xff]` list literal into a []byte.
+func toByteSlice(v ir.Value) ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	items, ok := v.([]ir.Value)
+	if !ok {
+		return nil, fmt.Errorf("expected a byte array literal, got %T", v)
+	}
+	out := make([]byte, 0, len(items))
+	for _, item := range items {
+		n, ok := item.(int64)
+		if !ok || n < 0 || n > 0xff {
+			return nil, fmt.Errorf("invalid byte literal %v", item)
+		}
+		out = append(out, byte(n))
+	}
+	return out, nil
+}
+
+// toHandleDispositions converts a parsed list of HandleDisposition records
+// into []ir.HandleDisposition.
+func toHandleDispositions(v ir.Value) ([]ir.HandleDisposition, error) {
+	if v == nil {
+		return nil, nil
+	}
+	items, ok := v.([]ir.Value)
+	if !ok {
+		return nil, fmt.Errorf("expected a list of handle dispositions, got %T", v)
+	}
+	out := make([]ir.HandleDisposition, 0, len(items))
+	for _, item := range items {
+		rec, ok := item.(ir.Record)
+		if !ok {
+			return nil, fmt.Errorf("expected a handle disposition record, got %T", item)
+		}
+		var hd ir.HandleDisposition
+		for _, field := range rec.Fields {
+			switch field.Name {
+			case "handle":
+				ref, ok := field.Value.(ir.HandleRef)
+				if !ok {
+					return nil, fmt.Errorf("handle disposition field %q: expected a handle reference", field.Name)
+				}
+				hd.Handle = ref.Index
+			case "type":
+				hd.Type = valueAsString(field.Value)
+			case "rights":
+				hd.Rights = valueAsString(field.Value)
+			}
+		}
+		out = append(out, hd)
+	}
+	return out, nil
+}
@@ -0,0 +1,160 @@
+// Copyright 2018 The Fuchsia Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package parser
+
+import (
+	"fidl/compiler/backend/gidl/ir"
+	"reflect"
+	"testing"
+)
+
+func TestParseValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  ir.Value
+	}{
+		{"string literal", `"hello"`, "hello"},
+		{"int literal", `42`, int64(42)},
+		{"negative int literal", `-1`, int64(-1)},
+		{"hex int literal", `0xff`, int64(0xff)},
+		{"float literal", `1.5`, float64(1.5)},
+		{"true", `true`, true},
+		{"false", `false`, false},
+		{"bare identifier", `MyEnum.FOO`, ir.Identifier("MyEnum.FOO")},
+		{"handle ref", `#0`, ir.HandleRef{Index: 0}},
+		{"empty byte list", `[]`, []ir.Value(nil)},
+		{"byte list", `[1, 0, 0xff]`, []ir.Value{int64(1), int64(0), int64(0xff)}},
+		{
+			"record",
+			`MyStruct{field: 1, other: "x"}`,
+			ir.Record{
+				Name: "MyStruct",
+				Fields: []ir.Field{
+					{Name: "field", Value: int64(1)},
+					{Name: "other", Value: "x"},
+				},
+			},
+		},
+		{
+			"nested record",
+			`Outer{inner: Inner{value: 1}}`,
+			ir.Record{
+				Name: "Outer",
+				Fields: []ir.Field{
+					{Name: "inner", Value: ir.Record{
+						Name:   "Inner",
+						Fields: []ir.Field{{Name: "value", Value: int64(1)}},
+					}},
+				},
+			},
+		},
+		{
+			"list of handle disposition records",
+			`[HandleDisposition{handle: #0, type: "CHANNEL", rights: "TRANSFER"}]`,
+			[]ir.Value{
+				ir.Record{
+					Name: "HandleDisposition",
+					Fields: []ir.Field{
+						{Name: "handle", Value: ir.HandleRef{Index: 0}},
+						{Name: "type", Value: "CHANNEL"},
+						{Name: "rights", Value: "TRANSFER"},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &parser{lex: newLexer(tc.input)}
+			if err := p.advance(); err != nil {
+				t.Fatalf("unexpected lex error: %v", err)
+			}
+			got, err := p.parseValue()
+			if err != nil {
+				t.Fatalf("unexpected parse error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("parseValue(%q) = %#v, want %#v", tc.input, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseValueErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"unterminated string", `"hello`},
+		{"dangling hash", `#`},
+		{"unexpected token", `:`},
+		{"unterminated list", `[1, 2`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &parser{lex: newLexer(tc.input)}
+			if err := p.advance(); err != nil {
+				return // lex error is an acceptable failure mode too
+			}
+			if _, err := p.parseValue(); err == nil {
+				t.Errorf("parseValue(%q): expected error, got none", tc.input)
+			}
+		})
+	}
+}
+
+func TestParseBlock(t *testing.T) {
+	input := `
+encode_success {
+	name: "basic",
+	value: MyStruct{field: 1},
+	bytes: [1, 0, 0, 0],
+	handle_dispositions: [HandleDisposition{handle: #0, type: "CHANNEL", rights: "TRANSFER"}],
+}
+decode_failure {
+	name: "bad presence",
+	bytes: [0xff],
+	err: INVALID_PRESENCE_INDICATOR,
+}
+`
+	all, err := Parse(input)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	if len(all.EncodeSuccess) != 1 {
+		t.Fatalf("got %d encode_success cases, want 1", len(all.EncodeSuccess))
+	}
+	es := all.EncodeSuccess[0]
+	if es.Name != "basic" {
+		t.Errorf("Name = %q, want %q", es.Name, "basic")
+	}
+	if !reflect.DeepEqual(es.Bytes, []byte{1, 0, 0, 0}) {
+		t.Errorf("Bytes = %v, want [1 0 0 0]", es.Bytes)
+	}
+	if len(es.HandleDispositions) != 1 || es.HandleDispositions[0] != (ir.HandleDisposition{Handle: 0, Type: "CHANNEL", Rights: "TRANSFER"}) {
+		t.Errorf("HandleDispositions = %+v, want a single CHANNEL/TRANSFER disposition for handle 0", es.HandleDispositions)
+	}
+
+	if len(all.DecodeFailure) != 1 {
+		t.Fatalf("got %d decode_failure cases, want 1", len(all.DecodeFailure))
+	}
+	df := all.DecodeFailure[0]
+	if df.Err != "INVALID_PRESENCE_INDICATOR" {
+		t.Errorf("Err = %q, want %q", df.Err, "INVALID_PRESENCE_INDICATOR")
+	}
+	if !reflect.DeepEqual(df.Bytes, []byte{0xff}) {
+		t.Errorf("Bytes = %v, want [0xff]", df.Bytes)
+	}
+}
+
+func TestParseUnknownBlockKind(t *testing.T) {
+	if _, err := Parse(`bogus_kind { name: "x" }`); err == nil {
+		t.Error("Parse: expected error for unknown block kind, got none")
+	}
+}
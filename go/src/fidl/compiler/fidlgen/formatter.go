@@ -0,0 +1,60 @@
+// Copyright 2018 The Fuchsia Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+// Package fidlgen holds helpers shared across the FIDL backends, analogous
+// to how the Go backend leans on the standard library's go/format package.
+package fidlgen
+
+import (
+	"bytes"
+	"os/exec"
+)
+
+// Formatter post-processes generated source before it is written to disk.
+type Formatter interface {
+	// Format runs the formatter over the given source, returning the
+	// formatted bytes or an error if the formatter failed.
+	Format(source []byte) ([]byte, error)
+}
+
+// ClangFormatFormatter formats C++ source by shelling out to an external
+// clang-format binary.
+type ClangFormatFormatter struct {
+	// Path is the path to the clang-format binary to invoke.
+	Path string
+}
+
+// NewClangFormatFormatter returns a Formatter backed by the clang-format
+// binary at path. If path is empty, Format is a no-op that returns its
+// input unchanged.
+func NewClangFormatFormatter(path string) Formatter {
+	return ClangFormatFormatter{Path: path}
+}
+
+func (f ClangFormatFormatter) Format(source []byte) ([]byte, error) {
+	if f.Path == "" {
+		return source, nil
+	}
+	cmd := exec.Command(f.Path, "-style=google")
+	cmd.Stdin = bytes.NewReader(source)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, formatError{err, stderr.String()}
+	}
+	return stdout.Bytes(), nil
+}
+
+type formatError struct {
+	err    error
+	stderr string
+}
+
+func (e formatError) Error() string {
+	if e.stderr == "" {
+		return e.err.Error()
+	}
+	return e.err.Error() + ": " + e.stderr
+}
@@ -0,0 +1,52 @@
+// Copyright 2018 The Fuchsia Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package fidlgen
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"strings"
+)
+
+// LazyWriter writes generated output to a file only when its contents
+// differ from what's already on disk, so an unchanged generated header
+// doesn't invalidate every translation unit that #includes it under
+// incremental build systems like Ninja/GN.
+type LazyWriter struct {
+	outputFilename string
+}
+
+// NewLazyWriter returns a LazyWriter that will conditionally write to
+// outputFilename.
+func NewLazyWriter(outputFilename string) (*LazyWriter, error) {
+	return &LazyWriter{outputFilename: outputFilename}, nil
+}
+
+// WriteFile writes contents to the LazyWriter's output file, unless the
+// file already holds those exact bytes.
+func (w *LazyWriter) WriteFile(contents []byte) error {
+	if existing, err := ioutil.ReadFile(w.outputFilename); err == nil {
+		if bytes.Equal(existing, contents) {
+			return nil
+		}
+	}
+	return ioutil.WriteFile(w.outputFilename, contents, ownerReadWriteNoExecute)
+}
+
+const ownerReadWriteNoExecute = 0644
+
+// WriteDepFile writes a Ninja/Make-style depfile declaring that target
+// depends on inputs, so incremental builds can skip regenerating target
+// when none of inputs have changed.
+func WriteDepFile(depfilePath, target string, inputs []string) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s:", target)
+	for _, input := range inputs {
+		fmt.Fprintf(&b, " %s", input)
+	}
+	b.WriteString("\n")
+	return ioutil.WriteFile(depfilePath, []byte(b.String()), ownerReadWriteNoExecute)
+}